@@ -0,0 +1,17 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// ExecutableSchema is implemented by the code generated for a user's schema.
+// A transport parses and validates an operation and then hands it off to one
+// of these methods to resolve.
+type ExecutableSchema interface {
+	Schema() *ast.Schema
+
+	Query(ctx context.Context, op *ast.OperationDefinition) *Response
+	Mutation(ctx context.Context, op *ast.OperationDefinition) *Response
+}
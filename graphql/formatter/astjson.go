@@ -0,0 +1,329 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Document is a JSON-friendly representation of an *ast.QueryDocument.
+//
+// ast.QueryDocument can't round-trip through encoding/json directly:
+// ast.SelectionSet holds the Selection interface (Field, FragmentSpread,
+// InlineFragment) and ast.Value is shaped similarly, and the stdlib decoder
+// has no way to pick a concrete type for an interface field. Document gives
+// every node a concrete, tagged-union shape instead, so ToDocument and
+// FromDocument can convert to and from the real AST gqlgen executes.
+type Document struct {
+	Operations []Operation `json:"operations"`
+	Fragments  []Fragment  `json:"fragments,omitempty"`
+}
+
+// Operation is the JSON shape of an *ast.OperationDefinition.
+type Operation struct {
+	Operation    string      `json:"operation"`
+	Name         string      `json:"name,omitempty"`
+	Variables    []Variable  `json:"variables,omitempty"`
+	SelectionSet []Selection `json:"selectionSet"`
+}
+
+// Fragment is the JSON shape of an *ast.FragmentDefinition.
+type Fragment struct {
+	Name          string      `json:"name"`
+	TypeCondition string      `json:"typeCondition"`
+	SelectionSet  []Selection `json:"selectionSet"`
+}
+
+// Variable is the JSON shape of an *ast.VariableDefinition. Type is the
+// GraphQL type reference in source form, e.g. "Int!" or "[String!]!".
+type Variable struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Selection is the JSON shape of an ast.Selection: exactly one of Field,
+// FragmentSpread or InlineFragment, picked by Kind.
+type Selection struct {
+	Kind          string      `json:"kind"`
+	Alias         string      `json:"alias,omitempty"`
+	Name          string      `json:"name,omitempty"`
+	Arguments     []Argument  `json:"arguments,omitempty"`
+	TypeCondition string      `json:"typeCondition,omitempty"`
+	SelectionSet  []Selection `json:"selectionSet,omitempty"`
+}
+
+// Argument is the JSON shape of an *ast.Argument.
+type Argument struct {
+	Name  string `json:"name"`
+	Value Value  `json:"value"`
+}
+
+// Value is the JSON shape of an *ast.Value. Kind is one of the ast.ValueKind
+// names (Variable, Int, Float, String, Block, Boolean, Null, Enum, List,
+// Object); Children holds list/object elements, recursively.
+type Value struct {
+	Kind     string       `json:"kind"`
+	Raw      string       `json:"raw,omitempty"`
+	Children []ChildValue `json:"children,omitempty"`
+}
+
+// ChildValue is one element of a List or Object Value; Name is set only for
+// Object children.
+type ChildValue struct {
+	Name  string `json:"name,omitempty"`
+	Value Value  `json:"value"`
+}
+
+// ToDocument converts a parsed *ast.QueryDocument into its JSON-friendly
+// wire shape.
+func ToDocument(doc *ast.QueryDocument) Document {
+	out := Document{}
+	for _, op := range doc.Operations {
+		out.Operations = append(out.Operations, Operation{
+			Operation:    string(op.Operation),
+			Name:         op.Name,
+			Variables:    toVariables(op.VariableDefinitions),
+			SelectionSet: toSelectionSet(op.SelectionSet),
+		})
+	}
+	for _, frag := range doc.Fragments {
+		out.Fragments = append(out.Fragments, Fragment{
+			Name:          frag.Name,
+			TypeCondition: frag.TypeCondition,
+			SelectionSet:  toSelectionSet(frag.SelectionSet),
+		})
+	}
+	return out
+}
+
+// FromDocument converts Document back into a real *ast.QueryDocument,
+// suitable for validating and executing against a schema.
+func FromDocument(d Document) (*ast.QueryDocument, error) {
+	doc := &ast.QueryDocument{}
+
+	for _, op := range d.Operations {
+		vars, err := fromVariables(op.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("operation %q: %w", op.Name, err)
+		}
+		sels, err := fromSelectionSet(op.SelectionSet)
+		if err != nil {
+			return nil, fmt.Errorf("operation %q: %w", op.Name, err)
+		}
+		doc.Operations = append(doc.Operations, &ast.OperationDefinition{
+			Operation:           ast.Operation(op.Operation),
+			Name:                op.Name,
+			VariableDefinitions: vars,
+			SelectionSet:        sels,
+		})
+	}
+
+	for _, frag := range d.Fragments {
+		sels, err := fromSelectionSet(frag.SelectionSet)
+		if err != nil {
+			return nil, fmt.Errorf("fragment %q: %w", frag.Name, err)
+		}
+		doc.Fragments = append(doc.Fragments, &ast.FragmentDefinition{
+			Name:          frag.Name,
+			TypeCondition: frag.TypeCondition,
+			SelectionSet:  sels,
+		})
+	}
+
+	return doc, nil
+}
+
+func toVariables(defs ast.VariableDefinitionList) []Variable {
+	out := make([]Variable, 0, len(defs))
+	for _, d := range defs {
+		out = append(out, Variable{Name: d.Variable, Type: d.Type.String()})
+	}
+	return out
+}
+
+func fromVariables(vars []Variable) (ast.VariableDefinitionList, error) {
+	out := make(ast.VariableDefinitionList, 0, len(vars))
+	for _, v := range vars {
+		t, err := parseType(v.Type)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %w", v.Name, err)
+		}
+		out = append(out, &ast.VariableDefinition{Variable: v.Name, Type: t})
+	}
+	return out, nil
+}
+
+func toSelectionSet(set ast.SelectionSet) []Selection {
+	out := make([]Selection, 0, len(set))
+	for _, sel := range set {
+		switch s := sel.(type) {
+		case *ast.Field:
+			out = append(out, Selection{
+				Kind:         "Field",
+				Alias:        s.Alias,
+				Name:         s.Name,
+				Arguments:    toArguments(s.Arguments),
+				SelectionSet: toSelectionSet(s.SelectionSet),
+			})
+		case *ast.FragmentSpread:
+			out = append(out, Selection{Kind: "FragmentSpread", Name: s.Name})
+		case *ast.InlineFragment:
+			out = append(out, Selection{
+				Kind:          "InlineFragment",
+				TypeCondition: s.TypeCondition,
+				SelectionSet:  toSelectionSet(s.SelectionSet),
+			})
+		}
+	}
+	return out
+}
+
+func fromSelectionSet(sels []Selection) (ast.SelectionSet, error) {
+	out := make(ast.SelectionSet, 0, len(sels))
+	for _, s := range sels {
+		switch s.Kind {
+		case "Field":
+			children, err := fromSelectionSet(s.SelectionSet)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, &ast.Field{
+				Alias:        s.Alias,
+				Name:         s.Name,
+				Arguments:    fromArguments(s.Arguments),
+				SelectionSet: children,
+			})
+		case "FragmentSpread":
+			out = append(out, &ast.FragmentSpread{Name: s.Name})
+		case "InlineFragment":
+			children, err := fromSelectionSet(s.SelectionSet)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, &ast.InlineFragment{
+				TypeCondition: s.TypeCondition,
+				SelectionSet:  children,
+			})
+		default:
+			return nil, fmt.Errorf("unknown selection kind %q", s.Kind)
+		}
+	}
+	return out, nil
+}
+
+func toArguments(args ast.ArgumentList) []Argument {
+	out := make([]Argument, 0, len(args))
+	for _, a := range args {
+		out = append(out, Argument{Name: a.Name, Value: toValue(a.Value)})
+	}
+	return out
+}
+
+func fromArguments(args []Argument) ast.ArgumentList {
+	out := make(ast.ArgumentList, 0, len(args))
+	for _, a := range args {
+		out = append(out, &ast.Argument{Name: a.Name, Value: fromValue(a.Value)})
+	}
+	return out
+}
+
+func toValue(v *ast.Value) Value {
+	if v == nil {
+		return Value{}
+	}
+
+	out := Value{Kind: valueKindName(v.Kind), Raw: v.Raw}
+	for _, c := range v.Children {
+		out.Children = append(out.Children, ChildValue{Name: c.Name, Value: toValue(c.Value)})
+	}
+	return out
+}
+
+func fromValue(v Value) *ast.Value {
+	out := &ast.Value{Kind: valueKindFromName(v.Kind), Raw: v.Raw}
+	for _, c := range v.Children {
+		out.Children = append(out.Children, &ast.ChildValue{Name: c.Name, Value: fromValue(c.Value)})
+	}
+	return out
+}
+
+func valueKindName(k ast.ValueKind) string {
+	switch k {
+	case ast.Variable:
+		return "Variable"
+	case ast.IntValue:
+		return "Int"
+	case ast.FloatValue:
+		return "Float"
+	case ast.StringValue:
+		return "String"
+	case ast.BlockValue:
+		return "Block"
+	case ast.BooleanValue:
+		return "Boolean"
+	case ast.NullValue:
+		return "Null"
+	case ast.EnumValue:
+		return "Enum"
+	case ast.ListValue:
+		return "List"
+	case ast.ObjectValue:
+		return "Object"
+	default:
+		return "String"
+	}
+}
+
+func valueKindFromName(s string) ast.ValueKind {
+	switch s {
+	case "Variable":
+		return ast.Variable
+	case "Int":
+		return ast.IntValue
+	case "Float":
+		return ast.FloatValue
+	case "Block":
+		return ast.BlockValue
+	case "Boolean":
+		return ast.BooleanValue
+	case "Null":
+		return ast.NullValue
+	case "Enum":
+		return ast.EnumValue
+	case "List":
+		return ast.ListValue
+	case "Object":
+		return ast.ObjectValue
+	default:
+		return ast.StringValue
+	}
+}
+
+// parseType parses a GraphQL type reference in source form, e.g. "Int!" or
+// "[String!]!", into an *ast.Type. It's the inverse of ast.Type.String.
+func parseType(s string) (*ast.Type, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("empty type")
+	}
+
+	nonNull := false
+	if strings.HasSuffix(s, "!") {
+		nonNull = true
+		s = s[:len(s)-1]
+	}
+
+	if strings.HasPrefix(s, "[") {
+		if !strings.HasSuffix(s, "]") {
+			return nil, fmt.Errorf("malformed list type %q", s)
+		}
+		elem, err := parseType(s[1 : len(s)-1])
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Type{Elem: elem, NonNull: nonNull}, nil
+	}
+
+	return &ast.Type{NamedType: s, NonNull: nonNull}, nil
+}
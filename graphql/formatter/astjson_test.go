@@ -0,0 +1,45 @@
+package formatter_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/formatter"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+func TestDocumentRoundTrip(t *testing.T) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: `
+		query Me($id: Int!) {
+			user(id: $id) {
+				name
+				...Details
+			}
+		}
+		fragment Details on User {
+			age
+		}
+	`})
+	assert.Nil(t, err)
+
+	wire := formatter.ToDocument(doc)
+
+	// The wire shape must itself survive a JSON round trip, since that's
+	// what actually crosses the transport.
+	b, jsonErr := json.Marshal(wire)
+	assert.NoError(t, jsonErr)
+
+	var decoded formatter.Document
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+
+	back, convErr := formatter.FromDocument(decoded)
+	assert.NoError(t, convErr)
+
+	var sb strings.Builder
+	formatter.NewFormatter(&sb).FormatQueryDocument(back)
+
+	assert.Equal(t, "query Me($id: Int!) {\n  user(id: $id) {\n    name\n    ...Details\n  }\n}\n\nfragment Details on User {\n  age\n}\n", sb.String())
+}
@@ -0,0 +1,135 @@
+// Package formatter renders a parsed GraphQL AST back into source text, the
+// inverse of what gqlparser does when it reads a query. It's the building
+// block for tooling like IDEs, linters and diff tools that want a
+// normalized, pretty-printed form of a query.
+package formatter
+
+import (
+	"io"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Formatter writes AST nodes to an underlying writer as GraphQL source text.
+type Formatter struct {
+	w      io.Writer
+	indent int
+}
+
+// NewFormatter creates a Formatter that writes to w.
+func NewFormatter(w io.Writer) *Formatter {
+	return &Formatter{w: w}
+}
+
+// FormatQueryDocument writes doc's operations and fragments back out as
+// normalized, indented GraphQL source text.
+func (f *Formatter) FormatQueryDocument(doc *ast.QueryDocument) {
+	for i, op := range doc.Operations {
+		if i > 0 {
+			f.writeString("\n")
+		}
+		f.formatOperation(op)
+	}
+
+	for _, frag := range doc.Fragments {
+		f.writeString("\n")
+		f.formatFragment(frag)
+	}
+}
+
+func (f *Formatter) writeString(s string) {
+	_, _ = io.WriteString(f.w, s)
+}
+
+func (f *Formatter) writeIndent() {
+	f.writeString(strings.Repeat("  ", f.indent))
+}
+
+func (f *Formatter) formatOperation(op *ast.OperationDefinition) {
+	// An anonymous query with no variables prints as shorthand ("{ ... }"),
+	// same as the query text a client would actually write by hand; the
+	// "query" keyword only earns its keep once there's a name or variables
+	// to attach it to.
+	if op.Operation == ast.Query && op.Name == "" && len(op.VariableDefinitions) == 0 {
+		f.formatSelectionSet(op.SelectionSet)
+		f.writeString("\n")
+		return
+	}
+
+	f.writeString(string(op.Operation))
+	if op.Name != "" {
+		f.writeString(" " + op.Name)
+	}
+
+	if len(op.VariableDefinitions) > 0 {
+		f.writeString("(")
+		for i, v := range op.VariableDefinitions {
+			if i > 0 {
+				f.writeString(", ")
+			}
+			f.writeString("$" + v.Variable + ": " + v.Type.String())
+		}
+		f.writeString(")")
+	}
+
+	f.writeString(" ")
+	f.formatSelectionSet(op.SelectionSet)
+	f.writeString("\n")
+}
+
+func (f *Formatter) formatFragment(frag *ast.FragmentDefinition) {
+	f.writeString("fragment " + frag.Name + " on " + frag.TypeCondition + " ")
+	f.formatSelectionSet(frag.SelectionSet)
+	f.writeString("\n")
+}
+
+func (f *Formatter) formatSelectionSet(set ast.SelectionSet) {
+	f.writeString("{\n")
+	f.indent++
+	for _, sel := range set {
+		f.writeIndent()
+		f.formatSelection(sel)
+		f.writeString("\n")
+	}
+	f.indent--
+	f.writeIndent()
+	f.writeString("}")
+}
+
+func (f *Formatter) formatSelection(sel ast.Selection) {
+	switch s := sel.(type) {
+	case *ast.Field:
+		if s.Alias != "" && s.Alias != s.Name {
+			f.writeString(s.Alias + ": ")
+		}
+		f.writeString(s.Name)
+
+		if len(s.Arguments) > 0 {
+			f.writeString("(")
+			for i, arg := range s.Arguments {
+				if i > 0 {
+					f.writeString(", ")
+				}
+				f.writeString(arg.Name + ": " + arg.Value.String())
+			}
+			f.writeString(")")
+		}
+
+		if len(s.SelectionSet) > 0 {
+			f.writeString(" ")
+			f.formatSelectionSet(s.SelectionSet)
+		}
+
+	case *ast.FragmentSpread:
+		f.writeString("..." + s.Name)
+
+	case *ast.InlineFragment:
+		f.writeString("...")
+		if s.TypeCondition != "" {
+			f.writeString(" on " + s.TypeCondition)
+		}
+		f.writeString(" ")
+		f.formatSelectionSet(s.SelectionSet)
+	}
+}
@@ -0,0 +1,21 @@
+package formatter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/formatter"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/parser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestFormatQueryDocument(t *testing.T) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: `query Me { me { name } }`})
+	assert.Nil(t, err)
+
+	var sb strings.Builder
+	formatter.NewFormatter(&sb).FormatQueryDocument(doc)
+
+	assert.Equal(t, "query Me {\n  me {\n    name\n  }\n}\n", sb.String())
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// Server wires an ExecutableSchema up to one or more wire transports.
+type Server struct {
+	es         graphql.ExecutableSchema
+	transports []transport.Transport
+	policies   []transport.AuthPolicy
+}
+
+// New creates a Server that will execute operations against es. Register at
+// least one transport.Transport with AddTransport before serving requests.
+func New(es graphql.ExecutableSchema) *Server {
+	return &Server{es: es}
+}
+
+// AddTransport registers t as a transport this server can serve incoming
+// requests with. Transports are tried in the order they were added, and the
+// first one whose Supports returns true handles the request.
+func (s *Server) AddTransport(t transport.Transport) {
+	s.transports = append(s.transports, t)
+}
+
+// Use registers policy to be checked against every operation this server
+// runs, regardless of which transport it arrives on. Policies run in the
+// order they were added, and the first one to deny a request wins.
+func (s *Server) Use(policy transport.AuthPolicy) {
+	s.policies = append(s.policies, policy)
+}
+
+// EnableIntrospectionTools turns on developer-tooling transports such as
+// transport.QueryTools. This is a deliberate, process-wide switch rather
+// than a per-Server option, so it never ends up flipped on by something a
+// production deployment does at request time.
+func EnableIntrospectionTools() {
+	transport.EnableIntrospectionTools()
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(transport.WithPolicies(r.Context(), s.policies))
+
+	for _, t := range s.transports {
+		if t.Supports(r) {
+			t.Do(w, r, s.es)
+			return
+		}
+	}
+
+	transport.WriteError(w, http.StatusBadRequest, "transport not supported")
+}
@@ -0,0 +1,137 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestJsonPostWithAuthPolicies(t *testing.T) {
+	es := &graphql.ExecutableSchemaMock{
+		QueryFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"name":"test"}`)}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return gqlparser.MustLoadSchema(&ast.Source{Input: `
+				schema { query: Query }
+				type Query {
+					me: User!
+				}
+				type User { name: String! }
+			`})
+		},
+	}
+	h := handler.New(es)
+	h.AddTransport(transport.JsonPostTransport{})
+	h.Use(transport.StaticToken("Authorization", "Bearer secret"))
+
+	t.Run("missing token is rejected", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+		assert.Equal(t, `{"errors":[{"message":"unauthorized"}],"data":null}`, resp.Body.String())
+	})
+
+	t.Run("correct token is allowed", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query":"{ me { name } }"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, w.Body.String())
+	})
+}
+
+func TestRequireOperationScopesPolicy(t *testing.T) {
+	es := &graphql.ExecutableSchemaMock{
+		MutationFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"ok":true}`)}
+		},
+		QueryFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"name":"test"}`)}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return gqlparser.MustLoadSchema(&ast.Source{Input: `
+				schema { query: Query, mutation: Mutation }
+				type Query { me: User! }
+				type Mutation { deleteAccount: Boolean! }
+				type User { name: String! }
+			`})
+		},
+	}
+	h := handler.New(es)
+	h.AddTransport(transport.JsonPostTransport{})
+	h.Use(transport.RequireOperation("deleteAccount", transport.StaticToken("Authorization", "Bearer secret")))
+
+	t.Run("unscoped operation is unaffected", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("scoped operation requires the token", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"query":"mutation deleteAccount { deleteAccount }","operationName":"deleteAccount"}`)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Equal(t, `{"errors":[{"message":"unauthorized"}],"data":null}`, resp.Body.String())
+	})
+
+	t.Run("scoped operation can't be reached by omitting operationName", func(t *testing.T) {
+		// The document has a single operation, so OperationList.ForName("")
+		// still resolves it even though the client never named it. The
+		// policy must be checked against that resolved name, not the
+		// (absent) wire field, or this request would sail through.
+		resp := doRequest(h, "POST", "/graphql", `{"query":"mutation deleteAccount { deleteAccount }"}`)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Equal(t, `{"errors":[{"message":"unauthorized"}],"data":null}`, resp.Body.String())
+	})
+}
+
+func TestIPAllowlistPolicy(t *testing.T) {
+	es := &graphql.ExecutableSchemaMock{
+		QueryFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"name":"test"}`)}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return gqlparser.MustLoadSchema(&ast.Source{Input: `
+				schema { query: Query }
+				type Query { me: User! }
+				type User { name: String! }
+			`})
+		},
+	}
+	h := handler.New(es)
+	h.AddTransport(transport.JsonPostTransport{})
+	h.Use(transport.IPAllowlist("10.0.0.0/8"))
+
+	t.Run("allowlisted client is allowed", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query":"{ me { name } }"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.RemoteAddr = "10.1.2.3:54321"
+		w := httptest.NewRecorder()
+
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, w.Body.String())
+	})
+
+	t.Run("non-allowlisted client is rejected", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query":"{ me { name } }"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.RemoteAddr = "192.168.1.1:54321"
+		w := httptest.NewRecorder()
+
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, `{"errors":[{"message":"forbidden: client IP not allowlisted"}],"data":null}`, w.Body.String())
+	})
+}
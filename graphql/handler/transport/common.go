@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+)
+
+// params is the wire representation of a single GraphQL operation, shared by
+// every transport that accepts a query/variables/operationName triple.
+type params struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// WriteError writes msg to w as a GraphQL error response with no data, the
+// fallback used when no registered transport can handle a request.
+func WriteError(w http.ResponseWriter, code int, msg string) {
+	writeJson(w, code, graphql.ErrorResponse(context.Background(), msg))
+}
+
+func writeJson(w http.ResponseWriter, code int, response *graphql.Response) {
+	b, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(b)
+}
+
+// run executes p against es and writes the resulting GraphQL response to w,
+// covering the parse -> validate -> execute pipeline shared by every
+// query/variables/operationName style transport.
+func run(es graphql.ExecutableSchema, w http.ResponseWriter, r *http.Request, p params) {
+	doc, errs := gqlparser.LoadQuery(es.Schema(), p.Query)
+	if errs != nil {
+		writeJson(w, http.StatusUnprocessableEntity, &graphql.Response{Errors: errs})
+		return
+	}
+
+	runOperation(es, w, r, doc.Operations.ForName(p.OperationName), p.Variables)
+}
+
+// runDocument is like run, but for a document the caller has already parsed
+// (e.g. received pre-parsed over the wire) rather than raw query text.
+func runDocument(es graphql.ExecutableSchema, w http.ResponseWriter, r *http.Request, doc *ast.QueryDocument, opName string, variables map[string]interface{}) {
+	runOperation(es, w, r, doc.Operations.ForName(opName), variables)
+}
+
+// runOperation checks policies, validates variables against op and executes
+// it, writing the resulting GraphQL response to w. op is nil when the
+// document has no operation matching the requested name.
+//
+// Policies are checked here, against op's resolved name, rather than by the
+// transports against the raw operationName field off the wire: a document
+// with a single operation resolves via OperationList.ForName regardless of
+// what name (or no name) the client asked for, so checking the unresolved
+// request field would let a client dodge a RequireOperation policy simply
+// by omitting operationName.
+func runOperation(es graphql.ExecutableSchema, w http.ResponseWriter, r *http.Request, op *ast.OperationDefinition, variables map[string]interface{}) {
+	if op == nil {
+		WriteError(w, http.StatusUnprocessableEntity, "operation not found")
+		return
+	}
+
+	if ok, status, resp := checkPolicies(r, op.Name); !ok {
+		writeJson(w, status, resp)
+		return
+	}
+
+	if _, err := graphql.VariableValues(es.Schema(), op, coerceVariables(variables)); err != nil {
+		writeJson(w, http.StatusUnprocessableEntity, &graphql.Response{Errors: gqlerror.List{err}})
+		return
+	}
+
+	var resp *graphql.Response
+	switch op.Operation {
+	case ast.Query:
+		resp = es.Query(r.Context(), op)
+	case ast.Mutation:
+		resp = es.Mutation(r.Context(), op)
+	default:
+		WriteError(w, http.StatusBadRequest, "unsupported operation type")
+		return
+	}
+
+	writeJson(w, http.StatusOK, resp)
+}
+
+// coerceVariables works around encoding/json always decoding a JSON number
+// into a float64: validator.VariableValues only accepts reflect.Int/Int32/
+// Int64 or String for an Int scalar, so a variable like {"id":1} would
+// otherwise fail coercion even though it's exactly the integer the schema
+// asked for. Any float64 with no fractional part is converted to int64
+// before validation; everything else (including non-integral floats, which
+// Int coercion should reject anyway) is left alone.
+func coerceVariables(variables map[string]interface{}) map[string]interface{} {
+	for k, v := range variables {
+		variables[k] = coerceVariable(v)
+	}
+	return variables
+}
+
+func coerceVariable(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if i := int64(v); float64(i) == v {
+			return i
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = coerceVariable(e)
+		}
+		return v
+	case map[string]interface{}:
+		return coerceVariables(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// UrlEncodedForm serves GraphQL over HTTP POST requests whose body is
+// application/x-www-form-urlencoded, with query, variables and
+// operationName carried as form fields, as supported by express-graphql and
+// other mainstream servers. variables is a JSON-encoded object, same as it
+// would appear in a JSON body.
+type UrlEncodedForm struct{}
+
+var _ Transport = UrlEncodedForm{}
+
+func (h UrlEncodedForm) Supports(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/x-www-form-urlencoded"
+}
+
+func (h UrlEncodedForm) Do(w http.ResponseWriter, r *http.Request, es graphql.ExecutableSchema) {
+	if err := r.ParseForm(); err != nil {
+		WriteError(w, http.StatusBadRequest, "unable to parse form body: "+err.Error())
+		return
+	}
+
+	p := params{
+		Query:         r.PostForm.Get("query"),
+		OperationName: r.PostForm.Get("operationName"),
+	}
+
+	if variables := r.PostForm.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &p.Variables); err != nil {
+			WriteError(w, http.StatusBadRequest, "variables could not be decoded: "+err.Error())
+			return
+		}
+	}
+
+	run(es, w, r, p)
+}
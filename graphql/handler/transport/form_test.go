@@ -0,0 +1,52 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestUrlEncodedForm(t *testing.T) {
+	es := &graphql.ExecutableSchemaMock{
+		QueryFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"name":"test"}`)}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return gqlparser.MustLoadSchema(&ast.Source{Input: `
+				schema { query: Query }
+				type Query {
+					user(id: Int): User!
+				}
+				type User { name: String! }
+			`})
+		},
+	}
+	h := handler.New(es)
+	h.AddTransport(transport.UrlEncodedForm{})
+
+	t.Run("success", func(t *testing.T) {
+		form := url.Values{"query": {`query($id:Int!){user(id:$id){name}}`}, "variables": {`{"id":1}`}}
+		resp := doRequestWithContentType(h, "POST", "/graphql", form.Encode(), "application/x-www-form-urlencoded")
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("invalid variables json", func(t *testing.T) {
+		form := url.Values{"query": {`{ user(id: 1) { name } }`}, "variables": {`not json`}}
+		resp := doRequestWithContentType(h, "POST", "/graphql", form.Encode(), "application/x-www-form-urlencoded")
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		resp := doRequestWithContentType(h, "POST", "/graphql", ``, "application/x-www-form-urlencoded")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+}
@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// GET serves GraphQL over HTTP GET requests, reading query, variables (as a
+// JSON-encoded string) and operationName from the URL query string. Because
+// the whole operation lives in the URL, responses can be cached by browsers
+// and CDNs the same way any other cacheable GET request can.
+//
+// Mutations are rejected by default, since a GET request should be safe to
+// retry or prefetch; set AllowMutations to change that.
+type GET struct {
+	// AllowMutations permits mutations to be executed over GET. Leave false
+	// unless you're sure every mutation this schema exposes is safe to
+	// expose this way.
+	AllowMutations bool
+
+	// SafelistedOnly, when true, only executes queries whose sha256 hash is
+	// present in Safelist, so operators can expose GET without letting
+	// clients run arbitrary queries through a CDN.
+	SafelistedOnly bool
+	Safelist       map[string]bool
+}
+
+var _ Transport = GET{}
+
+func (h GET) Supports(r *http.Request) bool {
+	return r.Method == http.MethodGet && r.URL.Query().Get("query") != ""
+}
+
+func (h GET) Do(w http.ResponseWriter, r *http.Request, es graphql.ExecutableSchema) {
+	query := r.URL.Query()
+
+	p := params{
+		Query:         query.Get("query"),
+		OperationName: query.Get("operationName"),
+	}
+
+	if variables := query.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &p.Variables); err != nil {
+			WriteError(w, http.StatusBadRequest, "variables could not be decoded: "+err.Error())
+			return
+		}
+	}
+
+	if h.SafelistedOnly && !h.isSafelisted(p.Query) {
+		WriteError(w, http.StatusForbidden, "query is not on the safelist")
+		return
+	}
+
+	doc, errs := gqlparser.LoadQuery(es.Schema(), p.Query)
+	if errs != nil {
+		writeJson(w, http.StatusUnprocessableEntity, &graphql.Response{Errors: errs})
+		return
+	}
+
+	op := doc.Operations.ForName(p.OperationName)
+	if op != nil && op.Operation == ast.Mutation && !h.AllowMutations {
+		WriteError(w, http.StatusMethodNotAllowed, "mutations are not allowed over GET")
+		return
+	}
+
+	runOperation(es, w, r, op, p.Variables)
+}
+
+func (h GET) isSafelisted(query string) bool {
+	sum := sha256.Sum256([]byte(query))
+	return h.Safelist[hex.EncodeToString(sum[:])]
+}
@@ -0,0 +1,87 @@
+package transport_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestGET(t *testing.T) {
+	es := &graphql.ExecutableSchemaMock{
+		QueryFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"name":"test"}`)}
+		},
+		MutationFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"ok":true}`)}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return gqlparser.MustLoadSchema(&ast.Source{Input: `
+				schema { query: Query, mutation: Mutation }
+				type Query { me: User! }
+				type Mutation { deleteAccount: Boolean! }
+				type User { name: String! }
+			`})
+		},
+	}
+
+	doGet := func(h http.Handler, rawQuery string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/graphql?"+rawQuery, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("success", func(t *testing.T) {
+		h := handler.New(es)
+		h.AddTransport(transport.GET{})
+
+		resp := doGet(h, url.Values{"query": {`{ me { name } }`}}.Encode())
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("mutations rejected by default", func(t *testing.T) {
+		h := handler.New(es)
+		h.AddTransport(transport.GET{})
+
+		resp := doGet(h, url.Values{"query": {`mutation { deleteAccount }`}}.Encode())
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	})
+
+	t.Run("mutations allowed when opted in", func(t *testing.T) {
+		h := handler.New(es)
+		h.AddTransport(transport.GET{AllowMutations: true})
+
+		resp := doGet(h, url.Values{"query": {`mutation { deleteAccount }`}}.Encode())
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("safelist rejects unknown queries", func(t *testing.T) {
+		h := handler.New(es)
+		h.AddTransport(transport.GET{SafelistedOnly: true, Safelist: map[string]bool{}})
+
+		resp := doGet(h, url.Values{"query": {`{ me { name } }`}}.Encode())
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("safelist allows known queries", func(t *testing.T) {
+		query := `{ me { name } }`
+		sum := sha256.Sum256([]byte(query))
+		h := handler.New(es)
+		h.AddTransport(transport.GET{SafelistedOnly: true, Safelist: map[string]bool{hex.EncodeToString(sum[:]): true}})
+
+		resp := doGet(h, url.Values{"query": {query}}.Encode())
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+}
@@ -0,0 +1,41 @@
+package transport
+
+import (
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// GraphQL serves GraphQL over HTTP POST requests whose body is the raw
+// query text, Content-Type: application/graphql, as supported by
+// express-graphql and other mainstream servers. Variables and an operation
+// name can't be supplied this way, so it's best suited to tooling that only
+// ever sends anonymous queries.
+type GraphQL struct{}
+
+var _ Transport = GraphQL{}
+
+func (h GraphQL) Supports(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/graphql"
+}
+
+func (h GraphQL) Do(w http.ResponseWriter, r *http.Request, es graphql.ExecutableSchema) {
+	query, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "unable to read request body: "+err.Error())
+		return
+	}
+
+	run(es, w, r, params{Query: string(query)})
+}
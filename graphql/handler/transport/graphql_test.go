@@ -0,0 +1,44 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestGraphQL(t *testing.T) {
+	es := &graphql.ExecutableSchemaMock{
+		QueryFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"name":"test"}`)}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return gqlparser.MustLoadSchema(&ast.Source{Input: `
+				schema { query: Query }
+				type Query {
+					me: User!
+				}
+				type User { name: String! }
+			`})
+		},
+	}
+	h := handler.New(es)
+	h.AddTransport(transport.GraphQL{})
+
+	t.Run("success", func(t *testing.T) {
+		resp := doRequestWithContentType(h, "POST", "/graphql", `{ me { name } }`, "application/graphql")
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		resp := doRequestWithContentType(h, "POST", "/graphql", ``, "application/graphql")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+}
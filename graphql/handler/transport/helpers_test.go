@@ -0,0 +1,21 @@
+package transport_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// doRequestWithContentType is like doRequest but lets the caller pick the
+// Content-Type header, for transports that key off of something other than
+// application/json.
+func doRequestWithContentType(handler http.Handler, method string, target string, body string, contentType string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+	return w
+}
@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// JsonPostTransport serves GraphQL over HTTP POST requests whose body is a
+// JSON object of the form {"query": "...", "operationName": "...", "variables": {...}}.
+// This is the de facto standard transport implemented by every mainstream
+// GraphQL server.
+type JsonPostTransport struct{}
+
+var _ Transport = JsonPostTransport{}
+
+func (h JsonPostTransport) Supports(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json"
+}
+
+func (h JsonPostTransport) Do(w http.ResponseWriter, r *http.Request, es graphql.ExecutableSchema) {
+	var p params
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		WriteError(w, http.StatusBadRequest, "json body could not be decoded: "+err.Error())
+		return
+	}
+
+	run(es, w, r, p)
+}
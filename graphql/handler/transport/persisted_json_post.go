@@ -0,0 +1,141 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/formatter"
+	"github.com/99designs/gqlgen/graphql/persisted"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+	"github.com/vektah/gqlparser/validator"
+)
+
+// persistedParams is the wire representation accepted by PersistedJsonPost.
+// Exactly one of Query, QueryId or AST is expected to be set, with the
+// exception of the APQ registration handshake, which sends Query and
+// Sha256Hash together.
+//
+// AST is a formatter.Document rather than an *ast.QueryDocument; see that
+// type's doc comment for why.
+type persistedParams struct {
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Query         string                 `json:"query"`
+	QueryId       string                 `json:"queryId"`
+	Sha256Hash    string                 `json:"sha256Hash"`
+	AST           *formatter.Document    `json:"ast"`
+}
+
+// parsedQuery is the cached result of parsing and validating a query, so a
+// queryId hit can skip straight to execution instead of redoing that work
+// on every request.
+type parsedQuery struct {
+	doc  *ast.QueryDocument
+	errs gqlerror.List
+}
+
+// PersistedJsonPost serves GraphQL over HTTP POST requests that, instead of
+// always sending the full query text, may send a previously registered
+// queryId/sha256Hash or a pre-parsed ast in its place. This lets high-QPS
+// clients skip repeated parsing and validation of the same handful of
+// operations, in the spirit of Apollo's automatic persisted queries.
+type PersistedJsonPost struct {
+	// Store looks up and registers query text by hash. Required.
+	Store persisted.Store
+
+	docCache sync.Map // hash -> *parsedQuery
+}
+
+var _ Transport = &PersistedJsonPost{}
+
+func (h *PersistedJsonPost) Supports(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json"
+}
+
+func (h *PersistedJsonPost) Do(w http.ResponseWriter, r *http.Request, es graphql.ExecutableSchema) {
+	var p persistedParams
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		WriteError(w, http.StatusBadRequest, "json body could not be decoded: "+err.Error())
+		return
+	}
+
+	switch {
+	case p.AST != nil:
+		h.runAST(es, w, r, p.AST, p.OperationName, p.Variables)
+
+	case p.QueryId != "":
+		query, ok := h.Store.Get(r.Context(), p.QueryId)
+		if !ok {
+			writeJson(w, http.StatusOK, graphql.ErrorResponse(r.Context(), "PersistedQueryNotFound"))
+			return
+		}
+		h.runCached(es, w, r, p.QueryId, query, p.OperationName, p.Variables)
+
+	case p.Query != "" && p.Sha256Hash != "":
+		if sum := sha256.Sum256([]byte(p.Query)); hex.EncodeToString(sum[:]) != p.Sha256Hash {
+			WriteError(w, http.StatusBadRequest, "provided sha256Hash does not match hash of query")
+			return
+		}
+		h.Store.Set(r.Context(), p.Sha256Hash, p.Query)
+		h.runCached(es, w, r, p.Sha256Hash, p.Query, p.OperationName, p.Variables)
+
+	default:
+		run(es, w, r, params{Query: p.Query, OperationName: p.OperationName, Variables: p.Variables})
+	}
+}
+
+// runAST converts a client-supplied AST into a real *ast.QueryDocument and
+// validates it against the schema before executing it. Unlike Query and
+// QueryId, an AST hasn't been through gqlparser.LoadQuery (which parses and
+// validates together), so a client could otherwise hand the generated
+// resolvers a document selecting fields or arguments the schema doesn't
+// have -- validation here closes that gap.
+func (h *PersistedJsonPost) runAST(es graphql.ExecutableSchema, w http.ResponseWriter, r *http.Request, wire *formatter.Document, opName string, variables map[string]interface{}) {
+	doc, err := formatter.FromDocument(*wire)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "ast could not be decoded: "+err.Error())
+		return
+	}
+
+	if errs := validator.Validate(es.Schema(), doc); errs != nil {
+		writeJson(w, http.StatusUnprocessableEntity, &graphql.Response{Errors: errs})
+		return
+	}
+
+	runDocument(es, w, r, doc, opName, variables)
+}
+
+// runCached parses and validates query on a cache miss for hash and reuses
+// the cached document (and any parse errors) on subsequent hits. Concurrent
+// misses for the same hash may each parse it; the first stored result wins.
+func (h *PersistedJsonPost) runCached(es graphql.ExecutableSchema, w http.ResponseWriter, r *http.Request, hash, query, opName string, variables map[string]interface{}) {
+	cached, ok := h.docCache.Load(hash)
+	if !ok {
+		doc, errs := gqlparser.LoadQuery(es.Schema(), query)
+		cached, _ = h.docCache.LoadOrStore(hash, &parsedQuery{doc: doc, errs: errs})
+	}
+
+	pq := cached.(*parsedQuery)
+	if pq.errs != nil {
+		writeJson(w, http.StatusUnprocessableEntity, &graphql.Response{Errors: pq.errs})
+		return
+	}
+
+	runOperation(es, w, r, pq.doc.Operations.ForName(opName), variables)
+}
@@ -0,0 +1,69 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/persisted"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestPersistedJsonPost(t *testing.T) {
+	es := &graphql.ExecutableSchemaMock{
+		QueryFunc: func(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+			return &graphql.Response{Data: []byte(`{"name":"test"}`)}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return gqlparser.MustLoadSchema(&ast.Source{Input: `
+				schema { query: Query }
+				type Query {
+					me: User!
+				}
+				type User { name: String! }
+			`})
+		},
+	}
+	h := handler.New(es)
+	store := persisted.NewInMemoryStore()
+	h.AddTransport(&transport.PersistedJsonPost{Store: store})
+
+	t.Run("full query", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("unregistered queryId", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"queryId":"does-not-exist"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"errors":[{"message":"PersistedQueryNotFound"}],"data":null}`, resp.Body.String())
+	})
+
+	t.Run("apq registration then lookup", func(t *testing.T) {
+		hash := "b8d9506e34c83b0e53c2aa463624fcea354713bc38f95276e6f0bd893ffb5b88"
+
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }","sha256Hash":"`+hash+`"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		resp = doRequest(h, "POST", "/graphql", `{"queryId":"`+hash+`"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("valid client-supplied ast", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"ast":{"operations":[{"operation":"query","selectionSet":[{"kind":"Field","name":"me","selectionSet":[{"kind":"Field","name":"name"}]}]}]}}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("invalid client-supplied ast is rejected by validation", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"ast":{"operations":[{"operation":"query","selectionSet":[{"kind":"Field","name":"doesNotExist"}]}]}}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+}
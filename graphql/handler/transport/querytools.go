@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/formatter"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+	"github.com/vektah/gqlparser/parser"
+)
+
+var introspectionToolsEnabled int32
+
+// EnableIntrospectionTools turns QueryTools on globally. Call it once, from
+// your server's setup code -- never from anything driven by a request --
+// since there's no way to turn it back off short of restarting the process.
+func EnableIntrospectionTools() {
+	atomic.StoreInt32(&introspectionToolsEnabled, 1)
+}
+
+func introspectionToolsAreEnabled() bool {
+	return atomic.LoadInt32(&introspectionToolsEnabled) == 1
+}
+
+// QueryTools exposes a developer-tooling endpoint for round-tripping between
+// GraphQL query text and its AST: {"query-to-ast": "..."} returns the
+// parsed AST as a formatter.Document, and {"ast-to-query": {...}} (that same
+// shape) returns a normalized, pretty-printed query string. It's meant for
+// building IDEs, linters and diff tools on top of a gqlgen schema, so it's
+// disabled unless EnableIntrospectionTools has been called, and never
+// serves requests otherwise.
+//
+// The wire format is formatter.Document rather than ast.QueryDocument
+// itself; see that type's doc comment for why.
+type QueryTools struct {
+	// Path is the URL path this transport answers on, e.g. "/query-tools".
+	Path string
+}
+
+var _ Transport = QueryTools{}
+
+type queryToolsParams struct {
+	QueryToAST string              `json:"query-to-ast"`
+	ASTToQuery *formatter.Document `json:"ast-to-query"`
+}
+
+func (h QueryTools) Supports(r *http.Request) bool {
+	if !introspectionToolsAreEnabled() {
+		return false
+	}
+
+	return r.Method == http.MethodPost && r.URL.Path == h.Path
+}
+
+func (h QueryTools) Do(w http.ResponseWriter, r *http.Request, es graphql.ExecutableSchema) {
+	var p queryToolsParams
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		WriteError(w, http.StatusBadRequest, "json body could not be decoded: "+err.Error())
+		return
+	}
+
+	switch {
+	case p.QueryToAST != "":
+		h.queryToAST(w, p.QueryToAST)
+	case p.ASTToQuery != nil:
+		h.astToQuery(w, p.ASTToQuery)
+	default:
+		WriteError(w, http.StatusBadRequest, `one of "query-to-ast" or "ast-to-query" is required`)
+	}
+}
+
+func (h QueryTools) queryToAST(w http.ResponseWriter, query string) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		writeJson(w, http.StatusUnprocessableEntity, &graphql.Response{Errors: gqlerror.List{err}})
+		return
+	}
+
+	b, jsonErr := json.Marshal(formatter.ToDocument(doc))
+	if jsonErr != nil {
+		panic(jsonErr)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+func (h QueryTools) astToQuery(w http.ResponseWriter, d *formatter.Document) {
+	doc, err := formatter.FromDocument(*d)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "ast could not be decoded: "+err.Error())
+		return
+	}
+
+	var sb strings.Builder
+	formatter.NewFormatter(&sb).FormatQueryDocument(doc)
+
+	b, jsonErr := json.Marshal(map[string]string{"query": sb.String()})
+	if jsonErr != nil {
+		panic(jsonErr)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
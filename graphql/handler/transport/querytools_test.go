@@ -0,0 +1,63 @@
+package transport_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestQueryTools(t *testing.T) {
+	es := &graphql.ExecutableSchemaMock{
+		SchemaFunc: func() *ast.Schema {
+			return gqlparser.MustLoadSchema(&ast.Source{Input: `
+				schema { query: Query }
+				type Query { me: User! }
+				type User { name: String! }
+			`})
+		},
+	}
+	h := handler.New(es)
+	h.AddTransport(transport.QueryTools{Path: "/query-tools"})
+
+	doPost := func(h http.Handler, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/query-tools", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		resp := doPost(h, `{"query-to-ast":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, `{"errors":[{"message":"transport not supported"}],"data":null}`, resp.Body.String())
+	})
+
+	handler.EnableIntrospectionTools()
+
+	t.Run("query-to-ast", func(t *testing.T) {
+		resp := doPost(h, `{"query-to-ast":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	})
+
+	t.Run("ast-to-query round trip", func(t *testing.T) {
+		astResp := doPost(h, `{"query-to-ast":"{ me { name } }"}`)
+		resp := doPost(h, `{"ast-to-query":`+astResp.Body.String()+`}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"query":"{\n  me {\n    name\n  }\n}\n"}`, resp.Body.String())
+	})
+
+	t.Run("ast-to-query rejects a malformed ast", func(t *testing.T) {
+		resp := doPost(h, `{"ast-to-query":{"operations":[{"operation":"query","selectionSet":[{"kind":"bogus"}]}]}}`)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
@@ -0,0 +1,20 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// Transport is implemented by the various wire formats (JSON over POST, form
+// encoded bodies, GET query strings, ...) that a handler.Server can serve a
+// request with. A Server tries each registered Transport in turn and uses
+// the first one whose Supports returns true.
+type Transport interface {
+	// Supports reports whether this Transport knows how to handle r.
+	Supports(r *http.Request) bool
+
+	// Do parses, validates and executes the operation encoded in r against
+	// es, writing a GraphQL response to w.
+	Do(w http.ResponseWriter, r *http.Request, es graphql.ExecutableSchema)
+}
@@ -0,0 +1,36 @@
+package persisted
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryStore is a Store backed by a plain map, suitable for a single
+// instance or for tests. Production deployments that need to share the
+// cache across replicas should implement Store against redis or similar.
+type InMemoryStore struct {
+	mu      sync.RWMutex
+	queries map[string]string
+}
+
+var _ Store = (*InMemoryStore)(nil)
+
+// NewInMemoryStore creates an empty InMemoryStore.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{queries: map[string]string{}}
+}
+
+func (s *InMemoryStore) Get(ctx context.Context, hash string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query, ok := s.queries[hash]
+	return query, ok
+}
+
+func (s *InMemoryStore) Set(ctx context.Context, hash string, query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queries[hash] = query
+}
@@ -0,0 +1,17 @@
+// Package persisted implements storage for the automatic persisted queries
+// (APQ) pattern: clients send a hash of a query instead of its full text,
+// registering the text against that hash the first time it's seen.
+package persisted
+
+import "context"
+
+// Store looks up and registers query text by its sha256 hash. Implementations
+// are free to back this with memory, redis, or anything else a client's
+// scale demands.
+type Store interface {
+	// Get returns the query text previously Set against hash, if any.
+	Get(ctx context.Context, hash string) (query string, ok bool)
+
+	// Set registers query text against hash for future lookups.
+	Set(ctx context.Context, hash string, query string)
+}
@@ -0,0 +1,23 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vektah/gqlparser/gqlerror"
+)
+
+// Response is the result of executing a single GraphQL operation, ready to
+// be serialized back over a transport.
+type Response struct {
+	Errors gqlerror.List   `json:"errors,omitempty"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// ErrorResponse builds a Response carrying a single formatted error message
+// and no data, for use when an operation can't be run at all.
+func ErrorResponse(ctx context.Context, format string, args ...interface{}) *Response {
+	return &Response{
+		Errors: gqlerror.List{gqlerror.Errorf(format, args...)},
+	}
+}
@@ -0,0 +1,14 @@
+package graphql
+
+import (
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+	"github.com/vektah/gqlparser/validator"
+)
+
+// VariableValues coerces the raw variables map decoded from the wire into
+// the types declared by op's variable definitions, returning a gqlerror.Error
+// with a "variable" path entry when a value can't be coerced.
+func VariableValues(schema *ast.Schema, op *ast.OperationDefinition, variables map[string]interface{}) (map[string]interface{}, *gqlerror.Error) {
+	return validator.VariableValues(schema, op, variables)
+}